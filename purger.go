@@ -0,0 +1,74 @@
+/*
+Package ccu provides a Purger interface that abstracts over the CCU v2 and
+v3 APIs, so callers can write purge logic once and choose a backend — or
+swap between them — at configuration time.
+
+See the v2 and v3 subpackages for the underlying clients that the V2Adapter
+and V3Adapter types in this package wrap.
+*/
+package ccu
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnsupported is returned by Purger methods that have no equivalent on
+// the wrapped backend, such as QueueLength on a v3 client.
+var ErrUnsupported = errors.New("ccu: operation not supported by this backend")
+
+// A PurgeSpec describes a purge request in terms common to the v2 and v3
+// CCU APIs.
+type PurgeSpec struct {
+	// Action is one of "invalidate" (default) or "delete". v2 adapters
+	// translate "delete" to v2's "remove".
+	Action string
+
+	// Type is one of "url" (default), "cpcode" or "tag". v2 adapters
+	// translate "url" to v2's "arl".
+	Type string
+
+	// Network is one of "production" (default) or "staging". v2 adapters use
+	// it as the v2 PurgeRequest.Domain.
+	Network string
+
+	// Hostname identifies the domain from which content is purged, assuming
+	// Type is "url". Ignored by v2 adapters, which require fully-qualified
+	// URLs in Objects.
+	Hostname string
+
+	// Objects is the list of URLs, CP codes or tags to purge.
+	Objects []string
+}
+
+// A PurgeHandle identifies one or more previously submitted purges so their
+// status can later be queried with Purger.Status.
+type PurgeHandle struct {
+	// PurgeIDs holds one purge ID per sub-request the backend needed to
+	// submit spec.Objects.
+	PurgeIDs []string
+}
+
+// A PurgeStatus describes the current state of a purge.
+type PurgeStatus struct {
+	// Done reports whether every purge identified by the queried
+	// PurgeHandle has finished processing.
+	Done bool
+}
+
+// A Purger submits and tracks CCU purge requests, abstracting over the CCU
+// v2 and v3 APIs so callers can write purge logic once and select a backend
+// at configuration time.
+type Purger interface {
+	// Purge submits spec for purging and returns a handle that can be used
+	// to query its status.
+	Purge(ctx context.Context, spec PurgeSpec) (PurgeHandle, error)
+
+	// Status returns the current status of a purge previously submitted via
+	// Purge.
+	Status(ctx context.Context, handle PurgeHandle) (PurgeStatus, error)
+
+	// QueueLength returns the number of purges waiting to be processed.
+	// Backends with no queue concept return ErrUnsupported.
+	QueueLength(ctx context.Context) (int, error)
+}
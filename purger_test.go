@@ -0,0 +1,52 @@
+package ccu
+
+import "testing"
+
+var (
+	_ Purger = (*V2Adapter)(nil)
+	_ Purger = (*V3Adapter)(nil)
+)
+
+func TestV2Action(t *testing.T) {
+	cases := map[string]string{"": "invalidate", "invalidate": "invalidate", "delete": "remove"}
+	for in, want := range cases {
+		got, err := v2Action(in)
+		if err != nil {
+			t.Fatalf("v2Action(%q): %v", in, err)
+		}
+		if got != want {
+			t.Errorf("v2Action(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if _, err := v2Action("bogus"); err == nil {
+		t.Error("expected an error for an unsupported action")
+	}
+}
+
+func TestV3Action(t *testing.T) {
+	cases := map[string]string{"": "invalidate", "invalidate": "invalidate", "delete": "delete"}
+	for in, want := range cases {
+		got, err := v3Action(in)
+		if err != nil {
+			t.Fatalf("v3Action(%q): %v", in, err)
+		}
+		if got != want {
+			t.Errorf("v3Action(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if _, err := v3Action("bogus"); err == nil {
+		t.Error("expected an error for an unsupported action")
+	}
+}
+
+func TestTypeMapping(t *testing.T) {
+	if got := v2Type(""); got != "arl" {
+		t.Errorf("v2Type(\"\") = %q, want \"arl\"", got)
+	}
+	if got := v2Type("cpcode"); got != "cpcode" {
+		t.Errorf("v2Type(\"cpcode\") = %q, want \"cpcode\"", got)
+	}
+	if got := v3Type(""); got != "url" {
+		t.Errorf("v3Type(\"\") = %q, want \"url\"", got)
+	}
+}
@@ -0,0 +1,165 @@
+package ccu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// A BatchPurgeResponse aggregates the results of one or more purge requests
+// issued on behalf of a single caller-supplied PurgeRequest that was split to
+// stay within Client.MaxBodyBytes.
+type BatchPurgeResponse struct {
+	// PurgeIDs holds one purge ID per chunk that was successfully submitted.
+	PurgeIDs []string
+
+	// EstimatedSeconds is the longest EstimatedSeconds reported across all
+	// chunks.
+	EstimatedSeconds int
+}
+
+// Wait blocks until every purge in b completes, or ctx is cancelled, polling
+// c for status. Purges are polled concurrently, bounded by Client.Concurrency.
+func (b *BatchPurgeResponse) Wait(ctx context.Context, c *Client, opts *WaitOptions) ([]*PurgeStatusResponse, error) {
+	results := make([]*PurgeStatusResponse, len(b.PurgeIDs))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, c.concurrency())
+	for i, id := range b.PurgeIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			v, err := c.WaitForPurge(ctx, id, opts)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("purge %s: %v", id, err))
+				mu.Unlock()
+				return
+			}
+			results[i] = v
+		}(i, id)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return results, &multiError{total: len(b.PurgeIDs), errs: errs}
+	}
+	return results, nil
+}
+
+// purgeChunks issues one Purge request per chunk concurrently, bounded by
+// Client.Concurrency, and aggregates the results into a BatchPurgeResponse.
+// If any chunk fails, the partial BatchPurgeResponse is still returned
+// alongside a multiError describing which chunks failed.
+func (c *Client) purgeChunks(req *PurgeRequest, chunks [][]string, ctx context.Context) (*BatchPurgeResponse, error) {
+	type result struct {
+		resp *PurgeResponse
+		err  error
+	}
+	results := make([]result, len(chunks))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.concurrency())
+	for i, objects := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, objects []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := c.purgeChunk(req, objects, ctx)
+			results[i] = result{resp: resp, err: err}
+		}(i, objects)
+	}
+	wg.Wait()
+
+	batch := &BatchPurgeResponse{}
+	var errs []error
+	for i, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("chunk %d: %v", i, r.err))
+			continue
+		}
+		batch.PurgeIDs = append(batch.PurgeIDs, r.resp.PurgeID)
+		if r.resp.EstimatedSeconds > batch.EstimatedSeconds {
+			batch.EstimatedSeconds = r.resp.EstimatedSeconds
+		}
+	}
+	if len(errs) > 0 {
+		return batch, &multiError{total: len(chunks), errs: errs}
+	}
+	return batch, nil
+}
+
+// chunkObjects splits req.Objects into the minimum number of slices whose
+// serialized PurgeRequest stays within maxBytes, accounting for the fixed
+// envelope (type, action, domain) rather than assuming a fixed object count.
+func chunkObjects(req *PurgeRequest, maxBytes int) ([][]string, error) {
+	remaining := req.Objects
+	var chunks [][]string
+	for len(remaining) > 0 {
+		n, err := maxObjectsFitting(req, remaining, maxBytes)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("ccu: object %q exceeds MaxBodyBytes (%d bytes) on its own", remaining[0], maxBytes)
+		}
+		chunks = append(chunks, remaining[:n])
+		remaining = remaining[n:]
+	}
+	return chunks, nil
+}
+
+// maxObjectsFitting binary searches for the largest prefix of objects whose
+// serialized request body fits within maxBytes.
+func maxObjectsFitting(req *PurgeRequest, objects []string, maxBytes int) (int, error) {
+	lo, hi := 0, len(objects)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		size, err := bodySize(req, objects[:mid])
+		if err != nil {
+			return 0, err
+		}
+		if size <= maxBytes {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
+
+func bodySize(req *PurgeRequest, objects []string) (int, error) {
+	clone := *req
+	clone.Objects = objects
+	b, err := json.Marshal(&clone)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding request as JSON: %v", err)
+	}
+	return len(b), nil
+}
+
+// multiError aggregates the errors from a batch of concurrent purge
+// operations so the caller can identify and retry only the failed chunks.
+type multiError struct {
+	total int
+	errs  []error
+}
+
+func (m *multiError) Error() string {
+	parts := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		parts[i] = err.Error()
+	}
+	return fmt.Sprintf("%d of %d purge chunks failed: %s", len(m.errs), m.total, strings.Join(parts, "; "))
+}
+
+// Errors returns the individual errors aggregated by m.
+func (m *multiError) Errors() []error {
+	return m.errs
+}
@@ -0,0 +1,46 @@
+package ccu
+
+import "testing"
+
+func TestChunkObjects(t *testing.T) {
+	req := NewPurgeRequest()
+	objects := make([]string, 2000)
+	for i := range objects {
+		objects[i] = "https://example.com/some/reasonably/long/path/to/a/resource.html"
+	}
+	req.Objects = objects
+
+	chunks, err := chunkObjects(req, 5000)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("expected more than one chunk, got %d", len(chunks))
+	}
+
+	var total int
+	for _, c := range chunks {
+		total += len(c)
+		size, err := bodySize(req, c)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if size > 5000 {
+			t.Errorf("chunk of %d objects produced a body of %d bytes, want <= 5000", len(c), size)
+		}
+	}
+	if total != len(objects) {
+		t.Errorf("expected chunks to cover all %d objects, got %d", len(objects), total)
+	}
+}
+
+func TestChunkObjectsSingleChunk(t *testing.T) {
+	req := NewPurgeRequest("/foo", "/bar")
+	chunks, err := chunkObjects(req, defaultMaxBodyBytes)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected a single chunk, got %d", len(chunks))
+	}
+}
@@ -11,11 +11,20 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 )
 
 const (
 	apiURL = "https://api.ccu.akamai.com"
+
+	// defaultMaxBodyBytes is the largest request body Akamai accepts for a
+	// single Purge call.
+	defaultMaxBodyBytes = 50000
+
+	// defaultConcurrency bounds how many chunked purge requests are issued
+	// at once when a PurgeRequest must be split.
+	defaultConcurrency = 5
 )
 
 var DefaultClient = &Client{
@@ -29,6 +38,34 @@ type Client struct {
 	Username   string
 	Password   string
 	Logger     *log.Logger
+
+	// MaxBodyBytes caps the size of a single Purge request body. A
+	// PurgeRequest whose Objects would produce a larger body is split into
+	// the minimum number of concurrent sub-requests that each fit. Defaults
+	// to 50,000 bytes, matching Akamai's limit.
+	MaxBodyBytes int
+
+	// Concurrency bounds how many chunked purge requests are in flight at
+	// once. Defaults to 5.
+	Concurrency int
+
+	// RetryPolicy configures how transient failures are retried. A nil
+	// RetryPolicy uses sensible defaults; see RetryPolicy for details.
+	RetryPolicy *RetryPolicy
+}
+
+func (c *Client) maxBodyBytes() int {
+	if c.MaxBodyBytes <= 0 {
+		return defaultMaxBodyBytes
+	}
+	return c.MaxBodyBytes
+}
+
+func (c *Client) concurrency() int {
+	if c.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+	return c.Concurrency
 }
 
 type Response struct {
@@ -38,6 +75,48 @@ type Response struct {
 	Detail      string `json:"detail"`
 	DescribedBy string `json:"describedBy"`
 	RawResponse string `json:"-"`
+
+	// RetryAfter is populated from the HTTP Retry-After response header, if
+	// present.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// retryAfterSetter is implemented by any type embedding Response, allowing
+// do to populate RetryAfter regardless of the concrete response type.
+type retryAfterSetter interface {
+	setRetryAfter(time.Duration)
+}
+
+func (e *Response) setRetryAfter(d time.Duration) {
+	e.RetryAfter = d
+}
+
+// rawResponseSetter is implemented by any type embedding Response, allowing
+// do to populate RawResponse regardless of the concrete response type.
+type rawResponseSetter interface {
+	setRawResponse(string)
+}
+
+func (e *Response) setRawResponse(s string) {
+	e.RawResponse = s
+}
+
+// parseRetryAfter parses the HTTP Retry-After header, which may be either a
+// number of seconds or an HTTP-date.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 func (e *Response) Error() string {
@@ -50,60 +129,117 @@ func (e *Response) Error() string {
 	return fmt.Sprintf("%s: %s", e.Title, e.Detail)
 }
 
-// assertError returns an error if the HTTP Status Code for the Response is not
-// in the 200 range.
+// assertError returns an *APIError if the HTTP Status Code for the Response
+// is not in the 200 range.
 func (r *Response) assertError() error {
 	if r.StatusCode < 200 || r.StatusCode > 299 {
-		return r
+		return newAPIError(*r)
 	}
 	return nil
 }
 
 // newHTTPRequest returns a http.Request with the given parameters. If v is not
-// nil, it is encoded as JSON in the request body.
+// nil, it is encoded as JSON in the request body and buffered so the request
+// can be safely replayed by do's retry logic.
 func (c *Client) newHTTPRequest(method, url string, v interface{}, ctx context.Context) (*http.Request, error) {
-	var body io.Reader
+	var bodyBytes []byte
 	if v != nil {
 		b := &bytes.Buffer{}
 		enc := json.NewEncoder(b)
 		if err := enc.Encode(v); err != nil {
 			return nil, fmt.Errorf("error encoding request as JSON: %v", err)
 		}
-		body = b
+		bodyBytes = b.Bytes()
+	}
+	var body io.Reader
+	if bodyBytes != nil {
+		body = bytes.NewReader(bodyBytes)
 	}
 	hreq, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP request: %v", err)
 	}
-	if body != nil {
+	if bodyBytes != nil {
 		hreq.Header.Set("Content-Type", "application/json")
+		hreq.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+		}
 	}
 	hreq.SetBasicAuth(c.Username, c.Password)
 	hreq = hreq.WithContext(ctx)
 	return hreq, nil
 }
 
-// do sends an HTTP request and returns an HTTP response. If v is not nil, the
-// body of the response if decoded as JSON into v.
+// do sends an HTTP request and returns an HTTP response, retrying transient
+// failures according to c.RetryPolicy. If v is not nil, the body of the
+// response is decoded as JSON into v.
 func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
-		return nil, fmt.Errorf("error sending HTTP request: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("unauthorized")
-	}
 	if v != nil {
-		dec := json.NewDecoder(resp.Body)
-		if err = dec.Decode(v); err != nil {
-			// TODO: include response body somewhere in error
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body: %v", err)
+		}
+		if rs, ok := v.(rawResponseSetter); ok {
+			rs.setRawResponse(string(b))
+		}
+		if err := json.Unmarshal(b, v); err != nil {
+			// A non-2xx response whose body isn't valid JSON (e.g. an HTML
+			// error page) must still surface as an *APIError, not a generic
+			// decode error, so callers can rely on errors.Is/As.
+			if apiErr := (&Response{StatusCode: resp.StatusCode, RawResponse: string(b), RetryAfter: parseRetryAfter(resp.Header)}).assertError(); apiErr != nil {
+				return nil, apiErr
+			}
 			return nil, fmt.Errorf("error decoding JSON response: %v", err)
 		}
+		if rs, ok := v.(retryAfterSetter); ok {
+			rs.setRetryAfter(parseRetryAfter(resp.Header))
+		}
 	}
 	return resp, nil
 }
 
+// doWithRetry sends req, retrying according to c.RetryPolicy on transient
+// network errors and retryable HTTP statuses. req's body must have been
+// buffered by newHTTPRequest so it can be replayed across attempts.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("error rewinding request body: %v", err)
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := c.HTTPClient.Do(attemptReq)
+		if retry, delay := policy.nextRetry(attempt, resp, err); retry {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(delay):
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error sending HTTP request: %v", err)
+		}
+		return resp, nil
+	}
+}
+
 type QueueLengthResponse struct {
 	Response
 
@@ -162,13 +298,41 @@ func (p *PurgeResponse) ETA() time.Time {
 	return p.Time.Add(time.Second * time.Duration(p.EstimatedSeconds))
 }
 
-func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*PurgeResponse, error) {
-	q := req.Queue
+// Purge submits req for purging.
+//
+// If req.Objects would produce a request body larger than
+// Client.MaxBodyBytes, it is split into the minimum number of sub-requests
+// that each fit and issued concurrently, bounded by Client.Concurrency. The
+// returned BatchPurgeResponse aggregates the purge ID of every chunk; for
+// requests that did not need to be split it contains exactly one.
+func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*BatchPurgeResponse, error) {
+	chunks, err := chunkObjects(req, c.maxBodyBytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) <= 1 {
+		resp, err := c.purgeChunk(req, req.Objects, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &BatchPurgeResponse{
+			PurgeIDs:         []string{resp.PurgeID},
+			EstimatedSeconds: resp.EstimatedSeconds,
+		}, nil
+	}
+	return c.purgeChunks(req, chunks, ctx)
+}
+
+// purgeChunk issues req with Objects replaced by objects.
+func (c *Client) purgeChunk(req *PurgeRequest, objects []string, ctx context.Context) (*PurgeResponse, error) {
+	chunk := *req
+	chunk.Objects = objects
+	q := chunk.Queue
 	if q == "" {
 		q = "default"
 	}
 	url := fmt.Sprintf("%s/ccu/v2/queues/%s", apiURL, q)
-	hreq, err := c.newHTTPRequest("POST", url, req, ctx)
+	hreq, err := c.newHTTPRequest("POST", url, &chunk, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -177,12 +341,11 @@ func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*PurgeResponse,
 	if err != nil {
 		return nil, err
 	}
-	err = v.Response.assertError()
-	if err != nil {
+	if err := v.Response.assertError(); err != nil {
 		return nil, err
 	}
 	v.Time = time.Now()
-	return v, err
+	return v, nil
 }
 
 type PurgeStatusResponse struct {
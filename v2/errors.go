@@ -0,0 +1,54 @@
+package ccu
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors classifying common CCU API failure modes. Test a returned
+// error against them with errors.Is, e.g.:
+//
+//	if errors.Is(err, ccu.ErrRateLimited) { ... }
+var (
+	ErrUnauthorized   = errors.New("ccu: unauthorized")
+	ErrForbidden      = errors.New("ccu: forbidden")
+	ErrRateLimited    = errors.New("ccu: rate limited")
+	ErrInvalidRequest = errors.New("ccu: invalid request")
+	ErrServerError    = errors.New("ccu: server error")
+)
+
+// An APIError wraps a failed API Response with additional context. It
+// satisfies errors.Is against the Err* sentinels above, based on
+// StatusCode.
+type APIError struct {
+	Response
+
+	// RetryAfter is copied from Response.RetryAfter for convenience.
+	RetryAfter time.Duration
+}
+
+// Is reports whether e should be treated as target by errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInvalidRequest:
+		return e.StatusCode >= 400 && e.StatusCode < 500 &&
+			e.StatusCode != http.StatusUnauthorized &&
+			e.StatusCode != http.StatusForbidden &&
+			e.StatusCode != http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// newAPIError builds an APIError from r.
+func newAPIError(r Response) *APIError {
+	return &APIError{Response: r, RetryAfter: r.RetryAfter}
+}
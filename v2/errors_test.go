@@ -0,0 +1,66 @@
+package ccu
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{401, ErrUnauthorized},
+		{403, ErrForbidden},
+		{429, ErrRateLimited},
+		{400, ErrInvalidRequest},
+		{404, ErrInvalidRequest},
+		{500, ErrServerError},
+		{503, ErrServerError},
+	}
+	for _, c := range cases {
+		err := newAPIError(Response{StatusCode: c.status})
+		if !errors.Is(err, c.want) {
+			t.Errorf("status %d: expected errors.Is to match %v", c.status, c.want)
+		}
+	}
+}
+
+func TestAssertErrorReturnsAPIError(t *testing.T) {
+	r := &Response{StatusCode: 401, Title: "Unauthorized"}
+	err := r.assertError()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected assertError to return an *APIError, got %T", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected errors.Is(err, ErrUnauthorized) to be true")
+	}
+}
+
+func TestDoReturnsAPIErrorOnUndecodableBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("<html>not json</html>"))
+	}))
+	defer srv.Close()
+
+	c := &Client{HTTPClient: srv.Client()}
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.do(req, &PurgeStatusResponse{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T: %v", err, err)
+	}
+	if !errors.Is(apiErr, ErrUnauthorized) {
+		t.Errorf("expected errors.Is(err, ErrUnauthorized) to be true")
+	}
+	if apiErr.RawResponse != "<html>not json</html>" {
+		t.Errorf("expected RawResponse to preserve the raw body, got %q", apiErr.RawResponse)
+	}
+}
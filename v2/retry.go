@@ -0,0 +1,135 @@
+package ccu
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 30 * time.Second
+)
+
+var defaultRetryableStatus = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// A RetryPolicy configures how Client.do retries a request that failed with
+// a transient network error or a retryable HTTP status.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Defaults to 3.
+	MaxAttempts int
+
+	// BaseDelay is the initial backoff delay, doubled on each subsequent
+	// attempt up to MaxDelay. Defaults to 500ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay between attempts. Defaults to 30s.
+	MaxDelay time.Duration
+
+	// RetryableStatus lists the HTTP status codes that are retried.
+	// Defaults to 408, 429, 500, 502, 503 and 504.
+	RetryableStatus []int
+
+	// ShouldRetry, if set, overrides the default retry decision for both
+	// network errors and HTTP responses.
+	ShouldRetry func(*http.Response, error) bool
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts <= 0 {
+		return defaultRetryMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) baseDelay() time.Duration {
+	if p == nil || p.BaseDelay <= 0 {
+		return defaultRetryBaseDelay
+	}
+	return p.BaseDelay
+}
+
+func (p *RetryPolicy) maxDelay() time.Duration {
+	if p == nil || p.MaxDelay <= 0 {
+		return defaultRetryMaxDelay
+	}
+	return p.MaxDelay
+}
+
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	statuses := defaultRetryableStatus
+	if p != nil && p.RetryableStatus != nil {
+		statuses = p.RetryableStatus
+	}
+	for _, s := range statuses {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry reports whether the attempt that produced resp/err should be
+// retried, ignoring MaxAttempts.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if p != nil && p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return netErr.Timeout() || netErr.Temporary()
+		}
+		return false
+	}
+	if resp == nil {
+		return false
+	}
+	return p.retryableStatus(resp.StatusCode)
+}
+
+// nextRetry reports whether the attempt'th attempt that produced resp/err
+// should be retried, and if so, how long to wait before the next one.
+func (p *RetryPolicy) nextRetry(attempt int, resp *http.Response, err error) (bool, time.Duration) {
+	if attempt >= p.maxAttempts() || !p.shouldRetry(resp, err) {
+		return false, 0
+	}
+	if resp != nil {
+		if d := parseRetryAfter(resp.Header); d > 0 {
+			return true, d
+		}
+	}
+	return true, fullJitter(exponentialDelay(p.baseDelay(), p.maxDelay(), attempt))
+}
+
+// exponentialDelay returns min(max, base*2^(attempt-1)).
+func exponentialDelay(base, max time.Duration, attempt int) time.Duration {
+	shift := attempt - 1
+	if shift > 20 {
+		shift = 20 // guard against overflow; well past MaxDelay by then
+	}
+	d := base * time.Duration(int64(1)<<uint(shift))
+	if d <= 0 || d > max {
+		d = max
+	}
+	return d
+}
+
+// fullJitter returns a random duration in [0, d].
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
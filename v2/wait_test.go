@@ -0,0 +1,31 @@
+package ccu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextInterval(t *testing.T) {
+	max := 60 * time.Second
+	d := nextInterval(2*time.Second, max)
+	if d < 3*time.Second || d > 5*time.Second {
+		t.Fatalf("expected ~4s with jitter, got %v", d)
+	}
+	d = nextInterval(50*time.Second, max)
+	if d > max {
+		t.Fatalf("expected interval to be capped at %v, got %v", max, d)
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	for _, code := range []int{429, 503, 500, 502} {
+		if !isRetryableStatus(code) {
+			t.Errorf("expected %d to be retryable", code)
+		}
+	}
+	for _, code := range []int{200, 400, 404} {
+		if isRetryableStatus(code) {
+			t.Errorf("expected %d to not be retryable", code)
+		}
+	}
+}
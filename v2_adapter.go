@@ -0,0 +1,87 @@
+package ccu
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/cavaliercoder/go-akamai-ccu/v2"
+)
+
+// A V2Adapter adapts a v2.Client to the Purger interface.
+type V2Adapter struct {
+	Client *v2.Client
+}
+
+// NewV2Purger returns a Purger backed by c.
+func NewV2Purger(c *v2.Client) Purger {
+	return &V2Adapter{Client: c}
+}
+
+// Purge implements Purger.
+func (a *V2Adapter) Purge(ctx context.Context, spec PurgeSpec) (PurgeHandle, error) {
+	action, err := v2Action(spec.Action)
+	if err != nil {
+		return PurgeHandle{}, err
+	}
+	domain := spec.Network
+	if domain == "" {
+		domain = "production"
+	}
+	req := &v2.PurgeRequest{
+		Type:    v2Type(spec.Type),
+		Action:  action,
+		Domain:  domain,
+		Objects: spec.Objects,
+	}
+	resp, err := a.Client.Purge(req, ctx)
+	if err != nil {
+		return PurgeHandle{}, err
+	}
+	return PurgeHandle{PurgeIDs: resp.PurgeIDs}, nil
+}
+
+// Status implements Purger.
+func (a *V2Adapter) Status(ctx context.Context, handle PurgeHandle) (PurgeStatus, error) {
+	for _, id := range handle.PurgeIDs {
+		resp, err := a.Client.GetPurgeStatus(id, ctx)
+		if err != nil {
+			return PurgeStatus{}, err
+		}
+		if !resp.IsDone() {
+			return PurgeStatus{Done: false}, nil
+		}
+	}
+	return PurgeStatus{Done: true}, nil
+}
+
+// QueueLength implements Purger.
+func (a *V2Adapter) QueueLength(ctx context.Context) (int, error) {
+	resp, err := a.Client.GetQueueLength(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return resp.QueueLength, nil
+}
+
+// v2Type translates a PurgeSpec.Type to the equivalent v2 PurgeRequest.Type.
+func v2Type(t string) string {
+	if t == "" || t == "url" {
+		return "arl"
+	}
+	return t
+}
+
+// v2Action translates a PurgeSpec.Action to the equivalent v2
+// PurgeRequest.Action.
+func v2Action(a string) (string, error) {
+	switch a {
+	case "":
+		return "invalidate", nil
+	case "invalidate":
+		return "invalidate", nil
+	case "delete":
+		return "remove", nil
+	default:
+		return "", fmt.Errorf("ccu: unsupported action %q for v2 backend", a)
+	}
+}
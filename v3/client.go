@@ -9,10 +9,14 @@ See: https://developer.akamai.com/api/purge/ccu/overview.html
 package ccu
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
@@ -23,6 +27,16 @@ var DefaultClient = &Client{
 	HTTPClient: http.DefaultClient,
 }
 
+const (
+	// defaultMaxBodyBytes is the largest request body Akamai accepts for a
+	// single Purge call.
+	defaultMaxBodyBytes = 50000
+
+	// defaultConcurrency bounds how many chunked purge requests are issued
+	// at once when a PurgeRequest must be split.
+	defaultConcurrency = 5
+)
+
 // A Client is an CCU v3 API client.
 type Client struct {
 	// HTTPClient is the http.Client used for all HTTP requests.
@@ -31,6 +45,34 @@ type Client struct {
 	// Config is the edgegrid.Config used to configure the authentication headers
 	// and API endpoint for all API requests.
 	Config *edgegrid.Config
+
+	// MaxBodyBytes caps the size of a single Purge request body. A
+	// PurgeRequest whose Objects would produce a larger body is split into
+	// the minimum number of concurrent sub-requests that each fit. Defaults
+	// to 50,000 bytes, matching Akamai's limit.
+	MaxBodyBytes int
+
+	// Concurrency bounds how many chunked purge requests are in flight at
+	// once. Defaults to 5.
+	Concurrency int
+
+	// RetryPolicy configures how transient failures are retried. A nil
+	// RetryPolicy uses sensible defaults; see RetryPolicy for details.
+	RetryPolicy *RetryPolicy
+}
+
+func (c *Client) maxBodyBytes() int {
+	if c.MaxBodyBytes <= 0 {
+		return defaultMaxBodyBytes
+	}
+	return c.MaxBodyBytes
+}
+
+func (c *Client) concurrency() int {
+	if c.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+	return c.Concurrency
 }
 
 // A Response is the base object containing fields common to all API responses.
@@ -54,6 +96,51 @@ type Response struct {
 
 	// DescribeBy is the URL for the APIâ€™s machine readable documentation
 	DescribedBy string `json:"describedBy"`
+
+	// RetryAfter is populated from the HTTP Retry-After response header, if
+	// present.
+	RetryAfter time.Duration `json:"-"`
+
+	// RawResponse holds the raw, undecoded response body.
+	RawResponse string `json:"-"`
+}
+
+// retryAfterSetter is implemented by any type embedding Response, allowing
+// do to populate RetryAfter regardless of the concrete response type.
+type retryAfterSetter interface {
+	setRetryAfter(time.Duration)
+}
+
+func (e *Response) setRetryAfter(d time.Duration) {
+	e.RetryAfter = d
+}
+
+// rawResponseSetter is implemented by any type embedding Response, allowing
+// do to populate RawResponse regardless of the concrete response type.
+type rawResponseSetter interface {
+	setRawResponse(string)
+}
+
+func (e *Response) setRawResponse(s string) {
+	e.RawResponse = s
+}
+
+// parseRetryAfter parses the HTTP Retry-After header, which may be either a
+// number of seconds or an HTTP-date.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 func (e *Response) Error() string {
@@ -66,11 +153,11 @@ func (e *Response) Error() string {
 	return fmt.Sprintf("%s: %s", e.Title, e.Detail)
 }
 
-// assertError returns an error if the HTTP Status Code for the Response is not
-// in the 200 range.
+// assertError returns an *APIError if the HTTP Status Code for the Response
+// is not in the 200 range.
 func (r *Response) assertError() error {
 	if r.StatusCode < 200 || r.StatusCode > 299 {
-		return r
+		return newAPIError(*r)
 	}
 	return nil
 }
@@ -90,9 +177,10 @@ func (c *Client) config() (*edgegrid.Config, error) {
 }
 
 // newHTTPRequest returns a http.Request with the given parameters. If v is not
-// nil, it is encoded as JSON in the request body.
-//
-// Authentication headers are appended according to the client.Config.
+// nil, it is encoded as JSON in the request body, which is buffered so it can
+// be replayed by do's retry logic. Authentication headers are NOT appended
+// here; do recomputes them on every attempt, since the edgegrid signature is
+// only valid for a short time.
 func (c *Client) newHTTPRequest(method, path string, v interface{}, ctx context.Context) (*http.Request, error) {
 	cfg, err := c.config()
 	if err != nil {
@@ -102,30 +190,98 @@ func (c *Client) newHTTPRequest(method, path string, v interface{}, ctx context.
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP request: %v", err)
 	}
-	hreq = edgegrid.AddRequestHeader(*cfg, hreq)
+	if hreq.Body != nil {
+		b, err := io.ReadAll(hreq.Body)
+		hreq.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error buffering request body: %v", err)
+		}
+		hreq.ContentLength = int64(len(b))
+		hreq.Body = io.NopCloser(bytes.NewReader(b))
+		hreq.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(b)), nil
+		}
+	}
 	if ctx != nil {
 		hreq = hreq.WithContext(ctx)
 	}
 	return hreq, nil
 }
 
-// do sends an HTTP request and returns an HTTP response. If v is not nil, the
-// body of the response if decoded as JSON into v.
+// do sends an HTTP request and returns an HTTP response, retrying transient
+// failures according to c.RetryPolicy and re-signing the request on every
+// attempt. If v is not nil, the body of the response is decoded as JSON
+// into v.
 func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.HTTPClient.Do(req)
+	cfg, err := c.config()
+	if err != nil {
+		return nil, fmt.Errorf("error reading edgegrid configuration: %v", err)
+	}
+	resp, err := c.doWithRetry(req, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error sending HTTP request: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if v != nil {
-		dec := json.NewDecoder(resp.Body)
-		if err = dec.Decode(v); err != nil {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return resp, fmt.Errorf("error reading response body: %v", err)
+		}
+		if rs, ok := v.(rawResponseSetter); ok {
+			rs.setRawResponse(string(b))
+		}
+		if err := json.Unmarshal(b, v); err != nil {
+			// A non-2xx response whose body isn't valid JSON (e.g. an HTML
+			// error page) must still surface as an *APIError, not a generic
+			// decode error, so callers can rely on errors.Is/As.
+			if apiErr := (&Response{StatusCode: resp.StatusCode, RawResponse: string(b), RetryAfter: parseRetryAfter(resp.Header)}).assertError(); apiErr != nil {
+				return resp, apiErr
+			}
 			return resp, fmt.Errorf("error decoding JSON response: %v", err)
 		}
+		if rs, ok := v.(retryAfterSetter); ok {
+			rs.setRetryAfter(parseRetryAfter(resp.Header))
+		}
 	}
 	return resp, nil
 }
 
+// doWithRetry sends req, re-signing it with a fresh edgegrid.AddRequestHeader
+// call on every attempt, and retries according to c.RetryPolicy on transient
+// network errors and retryable HTTP statuses. req's body must have been
+// buffered by newHTTPRequest so it can be replayed across attempts.
+func (c *Client) doWithRetry(req *http.Request, cfg *edgegrid.Config) (*http.Response, error) {
+	policy := c.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error rewinding request body: %v", err)
+			}
+			attemptReq.Body = body
+		}
+		attemptReq = edgegrid.AddRequestHeader(*cfg, attemptReq)
+
+		resp, err := c.HTTPClient.Do(attemptReq)
+		if retry, delay := policy.nextRetry(attempt, resp, err); retry {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(delay):
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error sending HTTP request: %v", err)
+		}
+		return resp, nil
+	}
+}
+
 // A PurgeRequest represents an API Purge request to be sent by a client.
 type PurgeRequest struct {
 	// Type must be one of "url" (default), "cpcode" or "tag".
@@ -159,9 +315,15 @@ type PurgeResponse struct {
 
 // Purge allows you to purge edge content.
 //
+// If req.Objects would produce a request body larger than
+// Client.MaxBodyBytes, it is split into the minimum number of sub-requests
+// that each fit and issued concurrently, bounded by Client.Concurrency. The
+// returned BatchPurgeResponse aggregates the purge ID of every chunk; for
+// requests that did not need to be split it contains exactly one.
+//
 // The given context.Context is used to allow cancellation of long running
 // requests.
-func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*PurgeResponse, error) {
+func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*BatchPurgeResponse, error) {
 	if req.Action == "" {
 		req.Action = "invalidate"
 	}
@@ -171,7 +333,36 @@ func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*PurgeResponse,
 	if req.Type == "" {
 		req.Type = "url"
 	}
-	hreq, err := c.newHTTPRequest("POST", req.Path(), req, ctx)
+
+	// Resolve the edgegrid config before any concurrent chunk requests are
+	// issued. c.Config is lazily initialized and cached by config(), which
+	// is not safe to call from multiple goroutines at once.
+	if _, err := c.config(); err != nil {
+		return nil, err
+	}
+
+	chunks, err := chunkObjects(req, c.maxBodyBytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) <= 1 {
+		resp, err := c.purgeChunk(req, req.Objects, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &BatchPurgeResponse{
+			PurgeIDs:         []string{resp.PurgeID},
+			EstimatedSeconds: resp.EstimatedSeconds,
+		}, nil
+	}
+	return c.purgeChunks(req, chunks, ctx)
+}
+
+// purgeChunk issues req with Objects replaced by objects.
+func (c *Client) purgeChunk(req *PurgeRequest, objects []string, ctx context.Context) (*PurgeResponse, error) {
+	chunk := *req
+	chunk.Objects = objects
+	hreq, err := c.newHTTPRequest("POST", chunk.Path(), &chunk, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -180,9 +371,8 @@ func (c *Client) Purge(req *PurgeRequest, ctx context.Context) (*PurgeResponse,
 	if err != nil {
 		return nil, err
 	}
-	err = v.Response.assertError()
-	if err != nil {
+	if err := v.Response.assertError(); err != nil {
 		return nil, err
 	}
-	return v, err
+	return v, nil
 }
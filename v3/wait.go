@@ -0,0 +1,168 @@
+package ccu
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// A PurgeStatusResponse describes the current state of a previously
+// submitted purge.
+type PurgeStatusResponse struct {
+	Response
+
+	PurgeID        string `json:"purgeId"`
+	PurgeStatus    string `json:"purgeStatus"`
+	SubmittedBy    string `json:"submittedBy"`
+	SubmissionTime string `json:"submissionTime"`
+	CompletionTime string `json:"completionTime"`
+}
+
+// IsDone reports whether the purge has finished processing.
+func (p *PurgeStatusResponse) IsDone() bool {
+	return p.PurgeStatus == "Done"
+}
+
+// GetPurgeStatus returns the status of a previously submitted purge.
+func (c *Client) GetPurgeStatus(purgeID string, ctx context.Context) (*PurgeStatusResponse, error) {
+	path := fmt.Sprintf("/ccu/v3/purges/%s", purgeID)
+	hreq, err := c.newHTTPRequest("GET", path, nil, ctx)
+	if err != nil {
+		return nil, err
+	}
+	v := &PurgeStatusResponse{}
+	_, err = c.do(hreq, v)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.Response.assertError(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+const (
+	defaultWaitMinInterval = 2 * time.Second
+	defaultWaitMaxInterval = 60 * time.Second
+	defaultWaitMaxRetries  = 5
+	waitJitterFraction     = 0.2
+)
+
+// WaitOptions configures the polling behavior of Client.WaitForPurge and
+// BatchPurgeResponse.Wait.
+type WaitOptions struct {
+	// MinInterval is the delay used to poll GetPurgeStatus when no better
+	// hint is available. Defaults to 2s.
+	MinInterval time.Duration
+
+	// MaxInterval caps the exponential backoff applied between polls.
+	// Defaults to 60s.
+	MaxInterval time.Duration
+
+	// MaxRetries is the number of consecutive 5xx/429 responses that are
+	// retried before giving up. Defaults to 5.
+	MaxRetries int
+
+	// Progress, if set, is called after every successful poll with the
+	// latest status.
+	Progress func(*PurgeStatusResponse)
+}
+
+func (o *WaitOptions) minInterval() time.Duration {
+	if o == nil || o.MinInterval <= 0 {
+		return defaultWaitMinInterval
+	}
+	return o.MinInterval
+}
+
+func (o *WaitOptions) maxInterval() time.Duration {
+	if o == nil || o.MaxInterval <= 0 {
+		return defaultWaitMaxInterval
+	}
+	return o.MaxInterval
+}
+
+func (o *WaitOptions) maxRetries() int {
+	if o == nil || o.MaxRetries <= 0 {
+		return defaultWaitMaxRetries
+	}
+	return o.MaxRetries
+}
+
+func (o *WaitOptions) notify(v *PurgeStatusResponse) {
+	if o != nil && o.Progress != nil {
+		o.Progress(v)
+	}
+}
+
+// jitter returns d adjusted by up to ±20%.
+func jitter(d time.Duration) time.Duration {
+	delta := float64(d) * waitJitterFraction
+	return d + time.Duration(delta*(2*rand.Float64()-1))
+}
+
+// nextInterval doubles last, caps it at max, and applies jitter without
+// exceeding max.
+func nextInterval(last, max time.Duration) time.Duration {
+	next := last * 2
+	if next <= 0 || next > max {
+		next = max
+	}
+	next = jitter(next)
+	if next > max {
+		next = max
+	}
+	if next < 0 {
+		next = 0
+	}
+	return next
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return code >= 500
+}
+
+// WaitForPurge polls GetPurgeStatus for purgeID until the purge is done or
+// ctx is cancelled. Polls back off exponentially between opts.MinInterval
+// and opts.MaxInterval with jitter, unless a Retry-After header on a failed
+// poll says otherwise.
+func (c *Client) WaitForPurge(ctx context.Context, purgeID string, opts *WaitOptions) (*PurgeStatusResponse, error) {
+	delay := opts.minInterval()
+
+	var retries int
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		v, err := c.GetPurgeStatus(purgeID, ctx)
+		if err != nil {
+			if apiErr, ok := err.(*APIError); ok && isRetryableStatus(apiErr.StatusCode) && retries < opts.maxRetries() {
+				retries++
+				if apiErr.RetryAfter > 0 {
+					delay = apiErr.RetryAfter
+				} else {
+					delay = nextInterval(delay, opts.maxInterval())
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		opts.notify(v)
+		if v.IsDone() {
+			return v, nil
+		}
+
+		retries = 0
+		delay = nextInterval(delay, opts.maxInterval())
+	}
+}
@@ -0,0 +1,61 @@
+package ccu
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+func TestNextInterval(t *testing.T) {
+	max := 60 * time.Second
+	d := nextInterval(2*time.Second, max)
+	if d < 3*time.Second || d > 5*time.Second {
+		t.Fatalf("expected ~4s with jitter, got %v", d)
+	}
+	d = nextInterval(50*time.Second, max)
+	if d > max {
+		t.Fatalf("expected interval to be capped at %v, got %v", max, d)
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	for _, code := range []int{429, 503, 500, 502} {
+		if !isRetryableStatus(code) {
+			t.Errorf("expected %d to be retryable", code)
+		}
+	}
+	for _, code := range []int{200, 400, 404} {
+		if isRetryableStatus(code) {
+			t.Errorf("expected %d to not be retryable", code)
+		}
+	}
+}
+
+func TestWaitForPurgePolls(t *testing.T) {
+	var polls int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) < 3 {
+			w.Write([]byte(`{"httpStatus":200,"purgeId":"abc","purgeStatus":"In-Progress"}`))
+			return
+		}
+		w.Write([]byte(`{"httpStatus":200,"purgeId":"abc","purgeStatus":"Done"}`))
+	}))
+	defer srv.Close()
+
+	c := &Client{HTTPClient: srv.Client(), Config: &edgegrid.Config{Host: srv.Listener.Addr().String()}}
+	v, err := c.WaitForPurge(context.Background(), "abc", &WaitOptions{MinInterval: time.Millisecond, MaxInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !v.IsDone() {
+		t.Errorf("expected the final status to be done")
+	}
+	if got := atomic.LoadInt32(&polls); got != 3 {
+		t.Errorf("expected 3 polls, got %d", got)
+	}
+}
@@ -0,0 +1,79 @@
+package ccu
+
+import (
+	"context"
+	"fmt"
+
+	v3 "github.com/cavaliercoder/go-akamai-ccu/v3"
+)
+
+// A V3Adapter adapts a v3.Client to the Purger interface.
+type V3Adapter struct {
+	Client *v3.Client
+}
+
+// NewV3Purger returns a Purger backed by c.
+func NewV3Purger(c *v3.Client) Purger {
+	return &V3Adapter{Client: c}
+}
+
+// Purge implements Purger.
+func (a *V3Adapter) Purge(ctx context.Context, spec PurgeSpec) (PurgeHandle, error) {
+	action, err := v3Action(spec.Action)
+	if err != nil {
+		return PurgeHandle{}, err
+	}
+	req := &v3.PurgeRequest{
+		Type:     v3Type(spec.Type),
+		Action:   action,
+		Network:  spec.Network,
+		Hostname: spec.Hostname,
+		Objects:  spec.Objects,
+	}
+	resp, err := a.Client.Purge(req, ctx)
+	if err != nil {
+		return PurgeHandle{}, err
+	}
+	return PurgeHandle{PurgeIDs: resp.PurgeIDs}, nil
+}
+
+// Status implements Purger.
+func (a *V3Adapter) Status(ctx context.Context, handle PurgeHandle) (PurgeStatus, error) {
+	for _, id := range handle.PurgeIDs {
+		resp, err := a.Client.GetPurgeStatus(id, ctx)
+		if err != nil {
+			return PurgeStatus{}, err
+		}
+		if !resp.IsDone() {
+			return PurgeStatus{Done: false}, nil
+		}
+	}
+	return PurgeStatus{Done: true}, nil
+}
+
+// QueueLength always returns ErrUnsupported: the v3 Fast Purge API has no
+// queue concept.
+func (a *V3Adapter) QueueLength(ctx context.Context) (int, error) {
+	return 0, ErrUnsupported
+}
+
+// v3Type translates a PurgeSpec.Type to the equivalent v3 PurgeRequest.Type.
+func v3Type(t string) string {
+	if t == "" {
+		return "url"
+	}
+	return t
+}
+
+// v3Action translates a PurgeSpec.Action to the equivalent v3
+// PurgeRequest.Action.
+func v3Action(a string) (string, error) {
+	switch a {
+	case "":
+		return "invalidate", nil
+	case "invalidate", "delete":
+		return a, nil
+	default:
+		return "", fmt.Errorf("ccu: unsupported action %q for v3 backend", a)
+	}
+}